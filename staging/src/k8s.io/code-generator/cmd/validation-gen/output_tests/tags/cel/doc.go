@@ -0,0 +1,22 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cel is a validation-gen output_tests fixture for the
+// +k8s:validation:cel marker, which lets a field carry an arbitrary CEL
+// expression as a validation rule -- the escape hatch for cross-field and
+// quantifier-based constraints the fixed marker set (+k8s:maxProperties,
+// +k8s:enum, etc.) can't express.
+package cel
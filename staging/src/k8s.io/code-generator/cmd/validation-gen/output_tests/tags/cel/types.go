@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+// Struct carries a cross-field rule spanning MinField and MaxField, in
+// addition to AnnotationsField's single-field rule, mirroring CRD's
+// object-level x-kubernetes-validations. validation.go's crossFieldProgram
+// and crossFieldMessageExpressionProgram hand-transcribe these two markers;
+// there is no generator in this tree to derive them from the tags below, so
+// edit validation.go to match if these change.
+// +k8s:validation:cel="self.minField < self.maxField"
+// +k8s:validation:messageExpression="'minField (' + string(self.minField) + ') must be less than maxField (' + string(self.maxField) + ')'"
+type Struct struct {
+	TypeMeta int
+
+	// AnnotationsField accepts at most 10 entries, all keyed with an "x-"
+	// prefix, mirroring CRD's x-kubernetes-validations escape hatch for
+	// constraints the fixed marker set (e.g. +k8s:maxProperties) can't
+	// express on its own.
+	// +k8s:validation:cel="self.size() <= 10 && self.all(k, k.startsWith('x-'))"
+	// +k8s:validation:message="annotationsField must have at most 10 entries, each key prefixed with 'x-'"
+	AnnotationsField map[string]string `json:"annotationsField"`
+
+	// MinField and MaxField are constrained relative to each other by
+	// Struct's cross-field +k8s:validation:cel rule above.
+	MinField int `json:"minField"`
+	MaxField int `json:"maxField"`
+}
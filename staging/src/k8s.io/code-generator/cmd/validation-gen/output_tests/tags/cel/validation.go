@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// annotationsFieldMessage mirrors the +k8s:validation:message marker on
+// AnnotationsField (types.go). There is no generator in this tree to keep
+// the two in sync, so this string must be updated by hand if that marker's
+// text changes.
+const annotationsFieldMessage = "annotationsField must have at most 10 entries, each key prefixed with 'x-'"
+
+// annotationsFieldProgram is the pre-compiled form of AnnotationsField's
+// +k8s:validation:cel expression (types.go), hand-transcribed here rather
+// than extracted from the marker -- this tree has no generator to produce
+// this program from the tag. It is compiled once, at package init, against
+// a "self" variable typed to match the field it validates, rather than
+// re-parsed on every call.
+var annotationsFieldProgram cel.Program
+
+func init() {
+	env, err := cel.NewEnv(cel.Variable("self", cel.MapType(cel.StringType, cel.StringType)))
+	if err != nil {
+		panic(err)
+	}
+	ast, iss := env.Compile(`self.size() <= 10 && self.all(k, k.startsWith('x-'))`)
+	if iss.Err() != nil {
+		panic(iss.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		panic(err)
+	}
+	annotationsFieldProgram = prg
+}
+
+// ValidateAnnotationsField runs AnnotationsField's compiled CEL rule,
+// short-circuiting (ratcheting) when the field is unchanged from oldValue.
+func ValidateAnnotationsField(fldPath *field.Path, value, oldValue map[string]string) field.ErrorList {
+	if oldValue != nil && reflect.DeepEqual(oldValue, value) {
+		return nil
+	}
+
+	out, _, err := annotationsFieldProgram.Eval(map[string]any{"self": value})
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if ok, isBool := out.Value().(bool); !isBool || !ok {
+		return field.ErrorList{field.Invalid(fldPath, value, annotationsFieldMessage)}
+	}
+	return nil
+}
+
+// crossFieldProgram is the pre-compiled, hand-transcribed form of Struct's
+// +k8s:validation:cel rule (types.go), a cross-field check spanning
+// MinField and MaxField -- the case a single-field marker like
+// +k8s:maxProperties can't express. As with annotationsFieldProgram, there
+// is no generator here to keep this in sync with the marker it mirrors.
+var crossFieldProgram cel.Program
+
+// crossFieldMessageExpressionProgram is the pre-compiled, hand-transcribed
+// form of Struct's companion +k8s:validation:messageExpression: a CEL
+// expression evaluated, in place of a static message, once the rule has
+// already failed.
+var crossFieldMessageExpressionProgram cel.Program
+
+func init() {
+	env, err := cel.NewEnv(cel.Variable("self", cel.MapType(cel.StringType, cel.IntType)))
+	if err != nil {
+		panic(err)
+	}
+
+	ast, iss := env.Compile(`self.minField < self.maxField`)
+	if iss.Err() != nil {
+		panic(iss.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		panic(err)
+	}
+	crossFieldProgram = prg
+
+	msgAst, iss := env.Compile(`"minField (" + string(self.minField) + ") must be less than maxField (" + string(self.maxField) + ")"`)
+	if iss.Err() != nil {
+		panic(iss.Err())
+	}
+	msgPrg, err := env.Program(msgAst)
+	if err != nil {
+		panic(err)
+	}
+	crossFieldMessageExpressionProgram = msgPrg
+}
+
+// ValidateStruct runs Struct's cross-field CEL rule, ratcheting when
+// MinField/MaxField are unchanged from old, and rendering its
+// +k8s:validation:messageExpression for the error detail on failure.
+func ValidateStruct(fldPath *field.Path, s, old *Struct) field.ErrorList {
+	if old != nil && old.MinField == s.MinField && old.MaxField == s.MaxField {
+		return nil
+	}
+
+	vars := map[string]any{"self": map[string]any{"minField": s.MinField, "maxField": s.MaxField}}
+	out, _, err := crossFieldProgram.Eval(vars)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, s, err.Error())}
+	}
+	if ok, isBool := out.Value().(bool); isBool && ok {
+		return nil
+	}
+
+	detail := "minField must be less than maxField"
+	if msgOut, _, err := crossFieldMessageExpressionProgram.Eval(vars); err == nil {
+		if rendered, isString := msgOut.Value().(string); isString {
+			detail = rendered
+		}
+	}
+	return field.ErrorList{field.Invalid(fldPath, s, detail)}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateAnnotationsField(t *testing.T) {
+	fldPath := field.NewPath("annotationsField")
+
+	if errs := ValidateAnnotationsField(fldPath, map[string]string{"x-a": "1", "x-b": "2"}, nil); len(errs) != 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	if errs := ValidateAnnotationsField(fldPath, map[string]string{"a": "1"}, nil); len(errs) == 0 {
+		t.Errorf("expected an error for a non-\"x-\"-prefixed key, got none")
+	}
+
+	big := make(map[string]string, 11)
+	for i := 0; i < 11; i++ {
+		big[string(rune('a'+i))] = "x-v"
+	}
+	if errs := ValidateAnnotationsField(fldPath, big, nil); len(errs) == 0 {
+		t.Errorf("expected an error for more than 10 entries, got none")
+	}
+}
+
+func TestValidateAnnotationsField_Ratcheting(t *testing.T) {
+	fldPath := field.NewPath("annotationsField")
+	invalid := map[string]string{"bad-key": "1"}
+
+	if errs := ValidateAnnotationsField(fldPath, invalid, invalid); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged invalid value, got %v", errs)
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	fldPath := field.NewPath("")
+
+	if errs := ValidateStruct(fldPath, &Struct{MinField: 1, MaxField: 2}, nil); len(errs) != 0 {
+		t.Errorf("expected no error when minField < maxField, got %v", errs)
+	}
+
+	errs := ValidateStruct(fldPath, &Struct{MinField: 5, MaxField: 2}, nil)
+	if len(errs) != 1 {
+		t.Fatalf("expected one error when minField >= maxField, got %v", errs)
+	}
+	if want := "minField (5) must be less than maxField (2)"; errs[0].Detail != want {
+		t.Errorf("expected the +k8s:validation:messageExpression detail %q, got %q", want, errs[0].Detail)
+	}
+}
+
+func TestValidateStruct_Ratcheting(t *testing.T) {
+	fldPath := field.NewPath("")
+	old := &Struct{MinField: 5, MaxField: 2}
+
+	if errs := ValidateStruct(fldPath, old, old); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged invalid value, got %v", errs)
+	}
+}
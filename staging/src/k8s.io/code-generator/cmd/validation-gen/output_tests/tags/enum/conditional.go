@@ -1,4 +1,3 @@
-
 /*
 Copyright 2025 The Kubernetes Authors.
 
@@ -17,11 +16,22 @@ limitations under the License.
 
 package enum
 
+// +k8s:union
 type ConditionalStruct struct {
 	TypeMeta int
 
+	// +k8s:unionDiscriminator
 	ConditionalEnumField    ConditionalEnum  `json:"conditionalEnumField"`
 	ConditionalEnumPtrField *ConditionalEnum `json:"conditionalEnumPtrField"`
+
+	// +k8s:unionMember=A
+	PayloadA *string `json:"payloadA,omitempty"`
+
+	// +k8s:unionMember=B
+	PayloadB *string `json:"payloadB,omitempty"`
+
+	// +k8s:unionMember=C
+	PayloadC *string `json:"payloadC,omitempty"`
 }
 
 // +k8s:enum
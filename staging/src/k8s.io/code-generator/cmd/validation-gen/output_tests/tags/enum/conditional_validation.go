@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enum
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// FeatureGateResolver reports whether a named feature gate is enabled. It is
+// threaded through these validators alongside field.Path wherever a
+// +k8s:ifEnabled/+k8s:ifDisabled marker is present on an enum constant, so
+// that ConditionalEnum-like types are enforced against live gate state and
+// not only the gate state assumed at generation time.
+type FeatureGateResolver interface {
+	Enabled(gate string) bool
+}
+
+// conditionalEnumExclusions is the lookup table ConditionalEnum's
+// +k8s:ifEnabled(X)=+k8s:enumExclude and +k8s:ifDisabled(X)=+k8s:enumExclude
+// markers (conditional.go) would produce: each func reports, given a
+// resolver, whether that value is currently forbidden. Multiple markers on
+// one constant combine with OR, matching the generation-time narrowing. This
+// table is hand-transcribed from those markers -- there is no generator in
+// this tree to keep the two in sync, so update it by hand if conditional.go's
+// markers change.
+var conditionalEnumExclusions = map[ConditionalEnum]func(FeatureGateResolver) bool{
+	ConditionalA: func(r FeatureGateResolver) bool { return r.Enabled("FeatureA") },
+	ConditionalB: func(r FeatureGateResolver) bool { return !r.Enabled("FeatureB") },
+	ConditionalD: func(r FeatureGateResolver) bool { return r.Enabled("FeatureA") || r.Enabled("FeatureB") },
+	ConditionalE: func(r FeatureGateResolver) bool { return !r.Enabled("FeatureC") || !r.Enabled("FeatureD") },
+	ConditionalF: func(r FeatureGateResolver) bool { return !r.Enabled("FeatureC") || r.Enabled("FeatureD") },
+}
+
+// ValidateConditionalEnumField validates a ConditionalEnum field against the
+// gates reported by resolver. It ratchets: if oldValue already held this
+// value, the (now possibly forbidden) value is let through unchanged.
+func ValidateConditionalEnumField(fldPath *field.Path, resolver FeatureGateResolver, value ConditionalEnum, oldValue *ConditionalEnum) field.ErrorList {
+	if oldValue != nil && *oldValue == value {
+		return nil
+	}
+	if excluded, ok := conditionalEnumExclusions[value]; ok && excluded(resolver) {
+		return field.ErrorList{field.NotSupported(fldPath, value, nil)}
+	}
+	return nil
+}
+
+// ValidateConditionalEnumPtrField is the pointer-field counterpart of
+// ValidateConditionalEnumField; a nil value is always valid.
+func ValidateConditionalEnumPtrField(fldPath *field.Path, resolver FeatureGateResolver, value, oldValue *ConditionalEnum) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	return ValidateConditionalEnumField(fldPath, resolver, *value, oldValue)
+}
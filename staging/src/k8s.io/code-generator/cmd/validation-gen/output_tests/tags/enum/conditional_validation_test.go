@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enum
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type fakeResolver map[string]bool
+
+func (f fakeResolver) Enabled(gate string) bool { return f[gate] }
+
+func TestValidateConditionalEnumField(t *testing.T) {
+	resolver := fakeResolver{"FeatureA": true, "FeatureB": false, "FeatureC": true, "FeatureD": false}
+
+	cases := []struct {
+		name      string
+		value     ConditionalEnum
+		expectErr bool
+	}{
+		{name: "excluded when FeatureA enabled", value: ConditionalA, expectErr: true},
+		{name: "excluded when FeatureB disabled", value: ConditionalB, expectErr: true},
+		{name: "always allowed", value: ConditionalC, expectErr: false},
+		{name: "excluded via either marker", value: ConditionalD, expectErr: true},
+		{name: "excluded when FeatureD disabled", value: ConditionalE, expectErr: true},
+		{name: "allowed when neither marker matches", value: ConditionalF, expectErr: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateConditionalEnumField(field.NewPath("conditionalEnumField"), resolver, tc.value, nil)
+			if tc.expectErr && len(errs) == 0 {
+				t.Errorf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectErr && len(errs) != 0 {
+				t.Errorf("expected no error for %q, got %v", tc.value, errs)
+			}
+		})
+	}
+}
+
+func TestValidateConditionalEnumField_Ratcheting(t *testing.T) {
+	resolver := fakeResolver{"FeatureA": true}
+	old := ConditionalA
+
+	errs := ValidateConditionalEnumField(field.NewPath("conditionalEnumField"), resolver, ConditionalA, &old)
+	if len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow a previously-set forbidden value, got %v", errs)
+	}
+}
+
+func TestValidateConditionalEnumPtrField_Nil(t *testing.T) {
+	resolver := fakeResolver{"FeatureA": true}
+
+	errs := ValidateConditionalEnumPtrField(field.NewPath("conditionalEnumPtrField"), resolver, nil, nil)
+	if len(errs) != 0 {
+		t.Errorf("expected nil pointer to always be valid, got %v", errs)
+	}
+}
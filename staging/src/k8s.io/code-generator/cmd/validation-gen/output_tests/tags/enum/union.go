@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enum
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// conditionalStructUnionMember is the form one +k8s:unionMember=X marker on
+// ConditionalStruct would take once extracted: the constant it selects, how
+// to tell whether its payload field is set, and how to read its value back
+// out for ratcheting comparisons.
+type conditionalStructUnionMember struct {
+	discriminator ConditionalEnum
+	fieldName     string
+	isSet         func(s *ConditionalStruct) bool
+	value         func(s *ConditionalStruct) *string
+}
+
+// conditionalStructUnionMembers is hand-transcribed from the
+// +k8s:unionMember markers on ConditionalStruct's payload fields
+// (conditional.go), in declaration order. There is no generator in this
+// tree to keep the two in sync, so update this by hand if those markers
+// change.
+var conditionalStructUnionMembers = []conditionalStructUnionMember{
+	{discriminator: ConditionalA, fieldName: "payloadA", isSet: func(s *ConditionalStruct) bool { return s.PayloadA != nil }, value: func(s *ConditionalStruct) *string { return s.PayloadA }},
+	{discriminator: ConditionalB, fieldName: "payloadB", isSet: func(s *ConditionalStruct) bool { return s.PayloadB != nil }, value: func(s *ConditionalStruct) *string { return s.PayloadB }},
+	{discriminator: ConditionalC, fieldName: "payloadC", isSet: func(s *ConditionalStruct) bool { return s.PayloadC != nil }, value: func(s *ConditionalStruct) *string { return s.PayloadC }},
+}
+
+// ValidateConditionalStructUnion enforces the +k8s:union on ConditionalStruct:
+// exactly one of the +k8s:unionMember payload fields must be set, it must be
+// the one selected by the +k8s:unionDiscriminator field, and a member whose
+// discriminator constant is currently excluded by the feature gates (via the
+// same +k8s:ifEnabled/+k8s:ifDisabled markers ConditionalEnum uses) is
+// forbidden even if the discriminator field names it. As with
+// ValidateConditionalEnumField, the gate-exclusion check ratchets: if old
+// already selected the same member, through the same discriminator value,
+// with the same payload, a no-op update is not re-rejected when a gate
+// flips underneath it.
+func ValidateConditionalStructUnion(fldPath *field.Path, resolver FeatureGateResolver, s, old *ConditionalStruct) field.ErrorList {
+	var set []conditionalStructUnionMember
+	for _, m := range conditionalStructUnionMembers {
+		if m.isSet(s) {
+			set = append(set, m)
+		}
+	}
+
+	if len(set) == 0 {
+		return field.ErrorList{field.Required(fldPath, "exactly one union member must be set")}
+	}
+	if len(set) > 1 {
+		return field.ErrorList{field.Invalid(fldPath, s.ConditionalEnumField, "exactly one union member must be set")}
+	}
+
+	member := set[0]
+	memberPath := fldPath.Child(member.fieldName)
+	if member.discriminator != s.ConditionalEnumField {
+		return field.ErrorList{field.Invalid(
+			memberPath, member.discriminator,
+			fmt.Sprintf("does not match discriminator value %q", s.ConditionalEnumField),
+		)}
+	}
+
+	if old != nil && old.ConditionalEnumField == member.discriminator &&
+		reflect.DeepEqual(conditionalStructUnionMemberValue(old, member.discriminator), member.value(s)) {
+		return nil
+	}
+	if excluded, ok := conditionalEnumExclusions[member.discriminator]; ok && excluded(resolver) {
+		return field.ErrorList{field.Invalid(
+			memberPath, member.discriminator,
+			fmt.Sprintf("member %q is forbidden by the current feature gates", member.discriminator),
+		)}
+	}
+	return nil
+}
+
+// conditionalStructUnionMemberValue returns the payload value s holds for
+// the union member selected by discriminator, or nil if discriminator is
+// unrecognized.
+func conditionalStructUnionMemberValue(s *ConditionalStruct, discriminator ConditionalEnum) *string {
+	for _, m := range conditionalStructUnionMembers {
+		if m.discriminator == discriminator {
+			return m.value(s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enum
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func ptr(s string) *string { return &s }
+
+func TestValidateConditionalStructUnion(t *testing.T) {
+	resolver := fakeResolver{"FeatureA": false, "FeatureB": false}
+	fldPath := field.NewPath("conditionalStruct")
+
+	if errs := ValidateConditionalStructUnion(fldPath, resolver, &ConditionalStruct{
+		ConditionalEnumField: ConditionalA,
+		PayloadA:             ptr("v"),
+	}, nil); len(errs) != 0 {
+		t.Errorf("expected a matching member to be valid, got %v", errs)
+	}
+
+	if errs := ValidateConditionalStructUnion(fldPath, resolver, &ConditionalStruct{}, nil); len(errs) == 0 {
+		t.Errorf("expected an error when no member is set, got none")
+	}
+
+	if errs := ValidateConditionalStructUnion(fldPath, resolver, &ConditionalStruct{
+		ConditionalEnumField: ConditionalA,
+		PayloadA:             ptr("v"),
+		PayloadB:             ptr("v"),
+	}, nil); len(errs) == 0 {
+		t.Errorf("expected an error when more than one member is set, got none")
+	}
+
+	if errs := ValidateConditionalStructUnion(fldPath, resolver, &ConditionalStruct{
+		ConditionalEnumField: ConditionalB,
+		PayloadA:             ptr("v"),
+	}, nil); len(errs) == 0 {
+		t.Errorf("expected an error when the set member doesn't match the discriminator, got none")
+	}
+
+	// FeatureA enabled excludes ConditionalA, so the member is forbidden even
+	// though the discriminator names it.
+	gated := fakeResolver{"FeatureA": true}
+	if errs := ValidateConditionalStructUnion(fldPath, gated, &ConditionalStruct{
+		ConditionalEnumField: ConditionalA,
+		PayloadA:             ptr("v"),
+	}, nil); len(errs) == 0 {
+		t.Errorf("expected an error when the member's discriminator is gate-excluded, got none")
+	}
+}
+
+func TestValidateConditionalStructUnion_Ratcheting(t *testing.T) {
+	fldPath := field.NewPath("conditionalStruct")
+	gated := fakeResolver{"FeatureA": true}
+
+	old := &ConditionalStruct{
+		ConditionalEnumField: ConditionalA,
+		PayloadA:             ptr("v"),
+	}
+	if errs := ValidateConditionalStructUnion(fldPath, gated, old, old); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged gate-excluded member, got %v", errs)
+	}
+
+	// Changing the payload value, even while keeping the same member
+	// selected, is not a no-op update, so the gate-exclusion check must
+	// re-trigger.
+	changed := &ConditionalStruct{
+		ConditionalEnumField: ConditionalA,
+		PayloadA:             ptr("w"),
+	}
+	if errs := ValidateConditionalStructUnion(fldPath, gated, changed, old); len(errs) == 0 {
+		t.Errorf("expected an edited payload on a gate-excluded member to be rejected, got none")
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maxproperties
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// BenchmarkValidateKeyPattern demonstrates that the per-entry cost of
+// +k8s:keyPattern stays constant as the map grows, since the pattern is
+// compiled once at package init rather than per validated entry. Keys are
+// generated to match keyPatternRegexp so the loop exercises the match path
+// rather than short-circuiting into an ErrorList allocation on every entry.
+func BenchmarkValidateKeyPattern(b *testing.B) {
+	fldPath := field.NewPath("patternConstrainedField")
+	for _, n := range []int{10, 1000, 100000} {
+		m := generateMatchingMapStringKeyStringWithLength(n)
+		b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ValidateKeyPattern(fldPath, m, nil)
+			}
+		})
+	}
+}
+
+func generateMatchingMapStringKeyStringWithLength(n int) map[StringKey]string {
+	out := make(map[StringKey]string, n)
+	for i := range n {
+		k := StringKey(fmt.Sprintf("x-%d", i))
+		out[k] = fmt.Sprintf("%d", i)
+	}
+	return out
+}
@@ -23,59 +23,109 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
-func Test(t *testing.T) {
-	st := localSchemeBuilder.Test(t)
-
-	st.Value(&Struct{
-		// All zero values
-	}).ExpectValid()
-
-	st.Value(&Struct{
-		Max0Field:            generateMapStringStringWithLength(0),
-		Max10Field:           generateMapStringStringWithLength(0),
-		Max0TypedefKeyField:  generateMapStringKeyStringWithLength(0),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(0),
-	}).ExpectValid()
-
-	st.Value(&Struct{
-		Max10Field:           generateMapStringStringWithLength(1),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(1),
-	}).ExpectValid()
-
-	st.Value(&Struct{
-		Max10Field:           generateMapStringStringWithLength(9),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(9),
-	}).ExpectValid()
+// expectErrors asserts that got holds exactly the errors in want, matched
+// by Type and Field only (not BadValue or Detail), order-independent.
+func expectErrors(t *testing.T, got, want field.ErrorList) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d errors, want %d\ngot:  %v\nwant: %v", len(got), len(want), got, want)
+	}
+	remaining := append(field.ErrorList{}, got...)
+	for _, w := range want {
+		found := -1
+		for i, g := range remaining {
+			if g.Type == w.Type && g.Field == w.Field {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			t.Errorf("no error matched type=%v field=%q\ngot:  %v\nwant: %v", w.Type, w.Field, got, want)
+			continue
+		}
+		remaining = append(remaining[:found], remaining[found+1:]...)
+	}
+}
 
-	st.Value(&Struct{
-		Max10Field:           generateMapStringStringWithLength(10),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(10),
-	}).ExpectValid()
+// Test exercises ValidateStruct end to end across every field in Struct, the
+// way a generated zz_generated.validations.go file's top-level Validate
+// would be exercised, rather than each field's validator in isolation.
+func Test(t *testing.T) {
+	var fldPath *field.Path
+
+	for _, s := range []*Struct{
+		{
+			// All zero values
+		},
+		{
+			Max0Field:            generateMapStringStringWithLength(0),
+			Max10Field:           generateMapStringStringWithLength(0),
+			Max0TypedefKeyField:  generateMapStringKeyStringWithLength(0),
+			Max10TypedefKeyField: generateMapStringKeyStringWithLength(0),
+		},
+		{
+			Max10Field:           generateMapStringStringWithLength(1),
+			Max10TypedefKeyField: generateMapStringKeyStringWithLength(1),
+		},
+		{
+			Max10Field:           generateMapStringStringWithLength(9),
+			Max10TypedefKeyField: generateMapStringKeyStringWithLength(9),
+		},
+		{
+			Max10Field:           generateMapStringStringWithLength(10),
+			Max10TypedefKeyField: generateMapStringKeyStringWithLength(10),
+		},
+		{
+			MinPropertiesField:      generateMapStringStringWithLength(2),
+			MaxItemsField:           []string{"a", "b", "c"},
+			MinItemsField:           []string{"a"},
+			UniqueItemsField:        []string{"a", "b", "c"},
+			PatternConstrainedField: map[StringKey]string{"x-foo": "1", "x-bar": "2"},
+		},
+	} {
+		if errs := ValidateStruct(fldPath, s, nil); len(errs) != 0 {
+			t.Errorf("ValidateStruct(%+v) = %v, want no errors", s, errs)
+		}
+	}
 
-	st.Value(&Struct{
+	invalid := &Struct{
 		Max0Field:            generateMapStringStringWithLength(1),
 		Max10Field:           generateMapStringStringWithLength(11),
 		Max0TypedefKeyField:  generateMapStringKeyStringWithLength(1),
 		Max10TypedefKeyField: generateMapStringKeyStringWithLength(11),
-	}).ExpectMatches(field.ErrorMatcher{}.ByType().ByField(), field.ErrorList{
+	}
+	expectErrors(t, ValidateStruct(fldPath, invalid, nil), field.ErrorList{
 		field.TooMany(field.NewPath("max0Field"), 1, 0),
 		field.TooMany(field.NewPath("max10Field"), 11, 10),
 		field.TooMany(field.NewPath("max0TypedefKeyField"), 1, 0),
 		field.TooMany(field.NewPath("max10TypedefKeyField"), 11, 10),
 	})
 
-	// Test validation ratcheting
-	st.Value(&Struct{
-		Max0Field:            generateMapStringStringWithLength(1),
-		Max10Field:           generateMapStringStringWithLength(11),
-		Max0TypedefKeyField:  generateMapStringKeyStringWithLength(1),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(11),
-	}).OldValue(&Struct{
-		Max0Field:            generateMapStringStringWithLength(1),
-		Max10Field:           generateMapStringStringWithLength(11),
-		Max0TypedefKeyField:  generateMapStringKeyStringWithLength(1),
-		Max10TypedefKeyField: generateMapStringKeyStringWithLength(11),
-	}).ExpectValid()
+	// Test validation ratcheting: an unchanged update to an already-invalid
+	// struct is not re-rejected.
+	if errs := ValidateStruct(fldPath, invalid, invalid); len(errs) != 0 {
+		t.Errorf("ValidateStruct(%+v) with unchanged old = %v, want no errors", invalid, errs)
+	}
+
+	invalid2 := &Struct{
+		MinPropertiesField:      generateMapStringStringWithLength(1),
+		MaxItemsField:           []string{"a", "b", "c", "d"},
+		MinItemsField:           []string{},
+		UniqueItemsField:        []string{"a", "b", "a"},
+		PatternConstrainedField: map[StringKey]string{"x-bar": "xyz"},
+	}
+	expectErrors(t, ValidateStruct(fldPath, invalid2, nil), field.ErrorList{
+		field.Invalid(field.NewPath("minPropertiesField"), nil, ""),
+		field.TooMany(field.NewPath("maxItemsField"), 4, 3),
+		field.Invalid(field.NewPath("minItemsField"), nil, ""),
+		field.Duplicate(field.NewPath("uniqueItemsField").Index(2), "a"),
+		field.Invalid(field.NewPath("patternConstrainedField").Key("x-bar"), "", ""),
+	})
+
+	// Test validation ratcheting for the size- and pattern-constraint family.
+	if errs := ValidateStruct(fldPath, invalid2, invalid2); len(errs) != 0 {
+		t.Errorf("ValidateStruct(%+v) with unchanged old = %v, want no errors", invalid2, errs)
+	}
 }
 
 func generateMapStringStringWithLength(n int) map[string]string {
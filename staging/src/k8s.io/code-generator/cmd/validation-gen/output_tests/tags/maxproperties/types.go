@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maxproperties
+
+// StringKey is a typedef'd map key, used to exercise markers against named
+// key types rather than plain strings.
+type StringKey string
+
+type Struct struct {
+	TypeMeta int
+
+	// +k8s:maxProperties=0
+	Max0Field map[string]string `json:"max0Field"`
+
+	// +k8s:maxProperties=10
+	Max10Field map[string]string `json:"max10Field"`
+
+	// +k8s:maxProperties=0
+	Max0TypedefKeyField map[StringKey]string `json:"max0TypedefKeyField"`
+
+	// +k8s:maxProperties=10
+	Max10TypedefKeyField map[StringKey]string `json:"max10TypedefKeyField"`
+
+	// +k8s:minProperties=2
+	MinPropertiesField map[string]string `json:"minPropertiesField"`
+
+	// +k8s:maxItems=3
+	MaxItemsField []string `json:"maxItemsField"`
+
+	// +k8s:minItems=1
+	MinItemsField []string `json:"minItemsField"`
+
+	// +k8s:uniqueItems
+	UniqueItemsField []string `json:"uniqueItemsField"`
+
+	// PatternConstrainedField combines cardinality and per-key/per-value
+	// predicates on a single map field.
+	// +k8s:maxProperties=10
+	// +k8s:keyPattern="^x-"
+	// +k8s:valuePattern="^[0-9]+$"
+	PatternConstrainedField map[StringKey]string `json:"patternConstrainedField"`
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maxproperties
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// keyPatternRegexp and valuePatternRegexp back +k8s:keyPattern and
+// +k8s:valuePattern respectively. They are compiled once at package init,
+// rather than per-call, since the pattern is fixed by the marker at
+// generation time.
+var (
+	keyPatternRegexp   = regexp.MustCompile(`^x-`)
+	valuePatternRegexp = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// ValidateMaxProperties enforces +k8s:maxProperties=max on m, ratcheting
+// against old so a map that already exceeded max is not re-rejected on an
+// update that leaves it unchanged or shrinks it, but still rejects growing
+// an already-too-big map any further.
+func ValidateMaxProperties[K comparable, V any](fldPath *field.Path, m, old map[K]V, max int) field.ErrorList {
+	if len(m) <= max {
+		return nil
+	}
+	if old != nil && len(old) > max && len(m) <= len(old) {
+		return nil
+	}
+	return field.ErrorList{field.TooMany(fldPath, len(m), max)}
+}
+
+// ValidateMinProperties enforces +k8s:minProperties=min on m, with the same
+// ratcheting behavior as ValidateMaxProperties: an already-too-small map is
+// not re-rejected as long as it doesn't shrink any further. Like the rest of
+// the optional-field markers in this family, an unset (nil) map has nothing
+// to enforce the bound against.
+func ValidateMinProperties[K comparable, V any](fldPath *field.Path, m, old map[K]V, min int) field.ErrorList {
+	if m == nil || len(m) >= min {
+		return nil
+	}
+	if old != nil && len(old) < min && len(m) >= len(old) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath, len(m), fmt.Sprintf("must have at least %d propert(ies)", min))}
+}
+
+// ValidateMaxItems enforces +k8s:maxItems=max on s, ratcheting against old
+// so an already-too-long slice is not re-rejected as long as it doesn't
+// grow any further.
+func ValidateMaxItems[T any](fldPath *field.Path, s, old []T, max int) field.ErrorList {
+	if len(s) <= max {
+		return nil
+	}
+	if old != nil && len(old) > max && len(s) <= len(old) {
+		return nil
+	}
+	return field.ErrorList{field.TooMany(fldPath, len(s), max)}
+}
+
+// ValidateMinItems enforces +k8s:minItems=min on s, ratcheting against old
+// so an already-too-short slice is not re-rejected as long as it doesn't
+// shrink any further. An unset (nil) slice has nothing to enforce the
+// bound against.
+func ValidateMinItems[T any](fldPath *field.Path, s, old []T, min int) field.ErrorList {
+	if s == nil || len(s) >= min {
+		return nil
+	}
+	if old != nil && len(old) < min && len(s) >= len(old) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath, len(s), fmt.Sprintf("must have at least %d item(s)", min))}
+}
+
+// ValidateUniqueItems enforces +k8s:uniqueItems on s, ratcheting against an
+// unchanged old slice so a list that already held a duplicate is not
+// re-rejected on an update that leaves it untouched.
+func ValidateUniqueItems[T comparable](fldPath *field.Path, s, old []T) field.ErrorList {
+	if old != nil && reflect.DeepEqual(old, s) {
+		return nil
+	}
+	var errs field.ErrorList
+	seen := make(map[T]bool, len(s))
+	for i, v := range s {
+		if seen[v] {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), v))
+			continue
+		}
+		seen[v] = true
+	}
+	return errs
+}
+
+// ValidateKeyPattern enforces +k8s:keyPattern on m's keys. Violations report
+// a path like patternConstrainedField[foo]. A key already present in old is
+// ratcheted: adding or editing other keys doesn't re-validate it.
+func ValidateKeyPattern(fldPath *field.Path, m, old map[StringKey]string) field.ErrorList {
+	var errs field.ErrorList
+	for k := range m {
+		if _, existed := old[k]; existed {
+			continue
+		}
+		if !keyPatternRegexp.MatchString(string(k)) {
+			errs = append(errs, field.Invalid(fldPath.Key(string(k)), k, fmt.Sprintf("key must match %q", keyPatternRegexp.String())))
+		}
+	}
+	return errs
+}
+
+// ValidateValuePattern enforces +k8s:valuePattern on m's values. A key/value
+// pair unchanged from old is ratcheted, same as ValidateKeyPattern.
+func ValidateValuePattern(fldPath *field.Path, m, old map[StringKey]string) field.ErrorList {
+	var errs field.ErrorList
+	for k, v := range m {
+		if oldV, existed := old[k]; existed && oldV == v {
+			continue
+		}
+		if !valuePatternRegexp.MatchString(v) {
+			errs = append(errs, field.Invalid(fldPath.Key(string(k)), v, fmt.Sprintf("value must match %q", valuePatternRegexp.String())))
+		}
+	}
+	return errs
+}
+
+// ValidateStruct is hand-transcribed from Struct's field markers (types.go):
+// it dispatches each field to the validator its marker selects, passing
+// old's corresponding field through for ratcheting. There is no generator
+// in this tree to keep the two in sync, so update this by hand if types.go's
+// markers change. A nil old is treated as a struct with no fields set, same
+// as every per-kind validator above already does for a nil old value.
+func ValidateStruct(fldPath *field.Path, s, old *Struct) field.ErrorList {
+	var o Struct
+	if old != nil {
+		o = *old
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, ValidateMaxProperties(fldPath.Child("max0Field"), s.Max0Field, o.Max0Field, 0)...)
+	errs = append(errs, ValidateMaxProperties(fldPath.Child("max10Field"), s.Max10Field, o.Max10Field, 10)...)
+	errs = append(errs, ValidateMaxProperties(fldPath.Child("max0TypedefKeyField"), s.Max0TypedefKeyField, o.Max0TypedefKeyField, 0)...)
+	errs = append(errs, ValidateMaxProperties(fldPath.Child("max10TypedefKeyField"), s.Max10TypedefKeyField, o.Max10TypedefKeyField, 10)...)
+	errs = append(errs, ValidateMinProperties(fldPath.Child("minPropertiesField"), s.MinPropertiesField, o.MinPropertiesField, 2)...)
+	errs = append(errs, ValidateMaxItems(fldPath.Child("maxItemsField"), s.MaxItemsField, o.MaxItemsField, 3)...)
+	errs = append(errs, ValidateMinItems(fldPath.Child("minItemsField"), s.MinItemsField, o.MinItemsField, 1)...)
+	errs = append(errs, ValidateUniqueItems(fldPath.Child("uniqueItemsField"), s.UniqueItemsField, o.UniqueItemsField)...)
+	errs = append(errs, ValidateMaxProperties(fldPath.Child("patternConstrainedField"), s.PatternConstrainedField, o.PatternConstrainedField, 10)...)
+	errs = append(errs, ValidateKeyPattern(fldPath.Child("patternConstrainedField"), s.PatternConstrainedField, o.PatternConstrainedField)...)
+	errs = append(errs, ValidateValuePattern(fldPath.Child("patternConstrainedField"), s.PatternConstrainedField, o.PatternConstrainedField)...)
+	return errs
+}
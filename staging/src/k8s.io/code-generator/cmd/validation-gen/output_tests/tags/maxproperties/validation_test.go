@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maxproperties
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateMaxProperties(t *testing.T) {
+	fldPath := field.NewPath("max10Field")
+
+	if errs := ValidateMaxProperties(fldPath, map[string]string{"a": "1"}, nil, 10); len(errs) != 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+	old := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6", "g": "7", "h": "8", "i": "9", "j": "10", "k": "11"}
+	if errs := ValidateMaxProperties(fldPath, old, nil, 10); len(errs) == 0 {
+		t.Errorf("expected a TooMany error, got none")
+	}
+	// Ratcheting: an already-too-big map is not re-rejected on an update
+	// that leaves it unchanged or shrinks it.
+	if errs := ValidateMaxProperties(fldPath, old, old, 10); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged over-max map, got %v", errs)
+	}
+	shrunk := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6", "g": "7", "h": "8", "i": "9", "j": "10"}
+	if errs := ValidateMaxProperties(fldPath, shrunk, old, 10); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow a shrunk over-max map, got %v", errs)
+	}
+	// Growing an already-too-big map further is still rejected.
+	grown := map[string]string{}
+	for k, v := range old {
+		grown[k] = v
+	}
+	grown["l"] = "12"
+	if errs := ValidateMaxProperties(fldPath, grown, old, 10); len(errs) == 0 {
+		t.Errorf("expected growing an over-max map to be rejected, got none")
+	}
+}
+
+func TestValidateMinProperties(t *testing.T) {
+	fldPath := field.NewPath("minPropertiesField")
+
+	if errs := ValidateMinProperties(fldPath, map[string]string{"a": "1", "b": "2"}, nil, 2); len(errs) != 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+	if errs := ValidateMinProperties(fldPath, map[string]string{"a": "1"}, nil, 2); len(errs) == 0 {
+		t.Errorf("expected a TooFew-style error, got none")
+	}
+	// Ratcheting: an already-too-small map is not re-rejected on update.
+	old := map[string]string{"a": "1"}
+	if errs := ValidateMinProperties(fldPath, map[string]string{"a": "1"}, old, 2); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged under-min map, got %v", errs)
+	}
+	// Growing an already-too-small map is still allowed.
+	if errs := ValidateMinProperties(fldPath, map[string]string{"a": "1", "z": "9"}, old, 3); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow a grown under-min map, got %v", errs)
+	}
+	// Shrinking an already-too-small map further is still rejected.
+	if errs := ValidateMinProperties[string, string](fldPath, map[string]string{}, old, 2); len(errs) == 0 {
+		t.Errorf("expected shrinking an under-min map to be rejected, got none")
+	}
+	// An unset (optional, nil) map has nothing to enforce the bound against.
+	if errs := ValidateMinProperties[string, string](fldPath, nil, nil, 2); len(errs) != 0 {
+		t.Errorf("expected an unset map to be valid, got %v", errs)
+	}
+}
+
+func TestValidateMaxItemsAndMinItems(t *testing.T) {
+	maxPath := field.NewPath("maxItemsField")
+	if errs := ValidateMaxItems(maxPath, []string{"a", "b", "c", "d"}, nil, 3); len(errs) == 0 {
+		t.Errorf("expected a TooMany error, got none")
+	}
+	// Ratcheting: an already-too-long slice is not re-rejected unless it
+	// grows further.
+	oldItems := []string{"a", "b", "c", "d"}
+	if errs := ValidateMaxItems(maxPath, oldItems, oldItems, 3); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged over-max slice, got %v", errs)
+	}
+	if errs := ValidateMaxItems(maxPath, append(append([]string{}, oldItems...), "e"), oldItems, 3); len(errs) == 0 {
+		t.Errorf("expected growing an over-max slice to be rejected, got none")
+	}
+
+	minPath := field.NewPath("minItemsField")
+	if errs := ValidateMinItems(minPath, []string{}, nil, 1); len(errs) == 0 {
+		t.Errorf("expected a too-few error, got none")
+	}
+	// An unset (optional, nil) slice has nothing to enforce the bound against.
+	if errs := ValidateMinItems[string](minPath, nil, nil, 1); len(errs) != 0 {
+		t.Errorf("expected an unset slice to be valid, got %v", errs)
+	}
+	// Ratcheting: an already-too-short slice is not re-rejected unless it
+	// shrinks further.
+	oldShort := []string{"a"}
+	if errs := ValidateMinItems(minPath, oldShort, oldShort, 2); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged under-min slice, got %v", errs)
+	}
+	if errs := ValidateMinItems(minPath, []string{}, oldShort, 2); len(errs) == 0 {
+		t.Errorf("expected shrinking an under-min slice to be rejected, got none")
+	}
+}
+
+func TestValidateUniqueItems(t *testing.T) {
+	fldPath := field.NewPath("uniqueItemsField")
+
+	if errs := ValidateUniqueItems(fldPath, []string{"a", "b", "c"}, nil); len(errs) != 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+	if errs := ValidateUniqueItems(fldPath, []string{"a", "b", "a"}, nil); len(errs) != 1 {
+		t.Errorf("expected one duplicate error, got %v", errs)
+	}
+}
+
+func TestValidateUniqueItems_Ratcheting(t *testing.T) {
+	fldPath := field.NewPath("uniqueItemsField")
+	old := []string{"a", "b", "a"}
+
+	if errs := ValidateUniqueItems(fldPath, old, old); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged duplicate, got %v", errs)
+	}
+}
+
+func TestValidateKeyPatternAndValuePattern(t *testing.T) {
+	fldPath := field.NewPath("patternConstrainedField")
+
+	m := map[StringKey]string{"x-foo": "123", "bar": "abc"}
+	errs := ValidateKeyPattern(fldPath, m, nil)
+	if len(errs) != 1 || errs[0].Field != "patternConstrainedField[bar]" {
+		t.Errorf("expected a single error for key \"bar\", got %v", errs)
+	}
+
+	errs = ValidateValuePattern(fldPath, m, nil)
+	if len(errs) != 1 || errs[0].Field != "patternConstrainedField[bar]" {
+		t.Errorf("expected a single error for value under key \"bar\", got %v", errs)
+	}
+}
+
+func TestValidateKeyPatternAndValuePattern_Ratcheting(t *testing.T) {
+	fldPath := field.NewPath("patternConstrainedField")
+	old := map[StringKey]string{"bar": "abc"}
+
+	if errs := ValidateKeyPattern(fldPath, old, old); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged invalid key, got %v", errs)
+	}
+	if errs := ValidateValuePattern(fldPath, old, old); len(errs) != 0 {
+		t.Errorf("expected ratcheting to allow an unchanged invalid value, got %v", errs)
+	}
+
+	// Changing the value at an existing key re-validates it.
+	changed := map[StringKey]string{"bar": "xyz"}
+	if errs := ValidateValuePattern(fldPath, changed, old); len(errs) != 1 {
+		t.Errorf("expected a re-validated error for an edited value, got %v", errs)
+	}
+}
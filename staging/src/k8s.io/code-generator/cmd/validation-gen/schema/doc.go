@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema exports the constraints declared through validation-gen's
+// tag markers (e.g. +k8s:enum, +k8s:maxProperties, +k8s:minLength) as an
+// OpenAPI v3 / JSONSchema document, mirroring the shape kubebuilder produces
+// in apiextensions.JSONSchemaProps. SchemaExporter and ParseFieldConstraints
+// operate on FieldMarkers and EnumConstant values describing those tag
+// markers, not the tag source itself; ExtractStructFieldMarkers and
+// ExtractEnumConstants (extract.go) build those values directly off a Go
+// source file's doc comments, the minimal real marker extraction a
+// validation-gen driver would need. There is still no standalone driver
+// command in this tree wiring the two together into a generated-on-build
+// schema.
+package schema
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markerLineRegexp matches a single +k8s:name=arg marker line the way the
+// validator packages themselves are written against, e.g.
+// +k8s:maxProperties=10 or +k8s:keyPattern="^x-". The argument, if any, is
+// unquoted by the caller.
+var markerLineRegexp = regexp.MustCompile(`^\+k8s:([A-Za-z]+)(?:=(.*))?$`)
+
+// ParseFieldMarkers extracts FieldMarkers from a single field's raw Go doc
+// comment text (as ast.CommentGroup.Text() returns it): one +k8s:marker or
+// +k8s:marker=arg per line. This is the same parsing
+// ExtractStructFieldMarkers applies per field when reading a source file
+// directly, split out so it can also be used on a doc comment obtained some
+// other way (e.g. in a test).
+func ParseFieldMarkers(doc string) FieldMarkers {
+	markers := FieldMarkers{}
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		m := markerLineRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := m[1]
+		arg := strings.Trim(m[2], `"`)
+		markers[name] = append(markers[name], arg)
+	}
+	return markers
+}
+
+// ExtractStructFieldMarkers parses the Go source file at path and returns
+// the FieldMarkers for every field of the named struct type, keyed by field
+// name, read directly off each field's doc comment; a field with no doc
+// comment has no entry in the result. This is the minimal
+// real marker extraction validation-gen's own driver would need to do --
+// unlike ParseFieldConstraints and SchemaExporter, which still only operate
+// on an already-parsed FieldMarkers/EnumConstant value.
+func ExtractStructFieldMarkers(path, typeName string) (map[string]FieldMarkers, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var found *ast.StructType
+	ast.Inspect(f, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != typeName {
+			return true
+		}
+		found, _ = ts.Type.(*ast.StructType)
+		return false
+	})
+	if found == nil {
+		return nil, fmt.Errorf("no struct type %q found in %s", typeName, path)
+	}
+
+	out := map[string]FieldMarkers{}
+	for _, field := range found.Fields.List {
+		if field.Doc == nil {
+			continue
+		}
+		markers := ParseFieldMarkers(field.Doc.Text())
+		for _, name := range field.Names {
+			out[name.Name] = markers
+		}
+	}
+	return out, nil
+}
+
+// gateConditionRegexp matches a +k8s:ifEnabled(Gate)=+k8s:enumExclude or
+// +k8s:ifDisabled(Gate)=+k8s:enumExclude marker line.
+var gateConditionRegexp = regexp.MustCompile(`^\+k8s:if(Enabled|Disabled)\(([A-Za-z0-9]+)\)=\+k8s:enumExclude$`)
+
+// ExtractEnumConstants parses the Go source file at path and returns the
+// EnumConstant list, in declaration order, for every constant of the named
+// +k8s:enum typedef, with exclusions read directly off each constant's
+// +k8s:ifEnabled/+k8s:ifDisabled doc-comment markers.
+func ExtractEnumConstants(path, typeName string) ([]EnumConstant, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var consts []EnumConstant
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) == 0 || len(vs.Names) != len(vs.Values) {
+				continue
+			}
+			ident, ok := vs.Type.(*ast.Ident)
+			if !ok || ident.Name != typeName {
+				continue
+			}
+
+			var exclusions []GateCondition
+			if vs.Doc != nil {
+				for _, line := range strings.Split(vs.Doc.Text(), "\n") {
+					m := gateConditionRegexp.FindStringSubmatch(strings.TrimSpace(line))
+					if m == nil {
+						continue
+					}
+					exclusions = append(exclusions, GateCondition{
+						Gate:           m[2],
+						RequireEnabled: m[1] == "Enabled",
+					})
+				}
+			}
+
+			// A grouped spec (e.g. "A, B ConditionalEnum = "A", "B"") shares
+			// one doc comment across all of its names; apply the same
+			// exclusions parsed from it to each constant in the group.
+			for i, name := range vs.Names {
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, fmt.Errorf("parsing %s: constant %s: %w", path, name.Name, err)
+				}
+				consts = append(consts, EnumConstant{Value: value, Exclusions: exclusions})
+			}
+		}
+	}
+	return consts, nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Schema is the subset of OpenAPI v3 / JSONSchema fields that validation-gen
+// knows how to populate from its tag markers. It deliberately mirrors the
+// field names of apiextensions.JSONSchemaProps so callers that already speak
+// CRD schemas don't have to learn a second vocabulary.
+type Schema struct {
+	Enum          []string `json:"enum,omitempty"`
+	MaxProperties *int64   `json:"maxProperties,omitempty"`
+	MinProperties *int64   `json:"minProperties,omitempty"`
+	MaxLength     *int64   `json:"maxLength,omitempty"`
+	MinLength     *int64   `json:"minLength,omitempty"`
+	Minimum       *float64 `json:"minimum,omitempty"`
+	Maximum       *float64 `json:"maximum,omitempty"`
+	OneOf         []Schema `json:"oneOf,omitempty"`
+}
+
+// FieldMarkers is the shape a field's markers take once extracted: marker
+// name (e.g. "maxProperties") to its raw argument strings, matching how
+// markers like +k8s:maxProperties=10 or +k8s:minLength=1 are written.
+// ExtractStructFieldMarkers (extract.go) builds a FieldMarkers straight off
+// a field's doc comment; callers that already have the text can also call
+// ParseFieldMarkers directly.
+type FieldMarkers map[string][]string
+
+// FieldConstraints is FieldMarkers after the same numeric parsing
+// validation-gen's generated validators apply, ready to render into a
+// Schema.
+type FieldConstraints struct {
+	MaxProperties *int64
+	MinProperties *int64
+	MaxLength     *int64
+	MinLength     *int64
+	Minimum       *float64
+	Maximum       *float64
+}
+
+// ParseFieldConstraints parses markers -- populated the same way the
+// maxproperties, minimum, maxLength, etc. validator packages' own markers
+// are written -- and produces the FieldConstraints to export as a schema.
+func ParseFieldConstraints(markers FieldMarkers) (FieldConstraints, error) {
+	var fc FieldConstraints
+	for name, args := range markers {
+		if len(args) != 1 {
+			return FieldConstraints{}, fmt.Errorf("marker %q: want exactly one argument, got %d", name, len(args))
+		}
+		arg := args[0]
+		switch name {
+		case "maxProperties":
+			v, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.MaxProperties = &v
+		case "minProperties":
+			v, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.MinProperties = &v
+		case "maxLength":
+			v, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.MaxLength = &v
+		case "minLength":
+			v, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.MinLength = &v
+		case "minimum":
+			v, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.Minimum = &v
+		case "maximum":
+			v, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return FieldConstraints{}, fmt.Errorf("marker %q: %w", name, err)
+			}
+			fc.Maximum = &v
+		default:
+			return FieldConstraints{}, fmt.Errorf("marker %q: not a size/range constraint", name)
+		}
+	}
+	return fc, nil
+}
+
+// SchemaExporter is the API a validation-gen driver would call while
+// walking a type's markers: ExportField for the size/length/numeric markers
+// on a struct field, ExportEnum for a +k8s:enum typedef's constants narrowed
+// against Gates, and ExportEnumVariants for the same constants across every
+// gate resolution (Gates is not consulted there). It consumes FieldMarkers and
+// EnumConstant, not Go source directly -- ExtractStructFieldMarkers and
+// ExtractEnumConstants (extract.go) are what read those values off the
+// actual doc comments.
+type SchemaExporter struct {
+	Gates GateSet
+}
+
+// ExportField renders markers' size and range constraints as a Schema.
+func (e SchemaExporter) ExportField(markers FieldMarkers) (Schema, error) {
+	fc, err := ParseFieldConstraints(markers)
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{
+		MaxProperties: fc.MaxProperties,
+		MinProperties: fc.MinProperties,
+		MaxLength:     fc.MaxLength,
+		MinLength:     fc.MinLength,
+		Minimum:       fc.Minimum,
+		Maximum:       fc.Maximum,
+	}, nil
+}
+
+// ExportEnum narrows consts against e.Gates; see the package-level ExportEnum.
+func (e SchemaExporter) ExportEnum(consts []EnumConstant) Schema {
+	return ExportEnum(consts, e.Gates)
+}
+
+// ExportEnumVariants emits a oneOf schema for consts; see the package-level
+// ExportEnumVariants.
+func (e SchemaExporter) ExportEnumVariants(consts []EnumConstant) Schema {
+	return ExportEnumVariants(consts)
+}
+
+// GateCondition is the parsed form of a single +k8s:ifEnabled(X)=+k8s:enumExclude
+// or +k8s:ifDisabled(X)=+k8s:enumExclude marker: the constant it is attached to
+// is excluded when gate Gate is in state RequireEnabled.
+type GateCondition struct {
+	Gate           string
+	RequireEnabled bool
+}
+
+// EnumConstant is one constant of a +k8s:enum typedef, along with the
+// exclusion markers parsed from its doc comment -- see ExtractEnumConstants
+// (extract.go), which builds these directly from a source file, or
+// ParseFieldMarkers-style hand population for callers that don't have one.
+// Multiple exclusions on the same constant combine with OR: any one of them
+// matching excludes the value.
+type EnumConstant struct {
+	Value      string
+	Exclusions []GateCondition
+}
+
+// GateSet is the set of feature gates known at schema-generation time, along
+// with whether each is enabled. A gate absent from the map is "unknown" at
+// generation time, not "disabled" -- see excluded below.
+type GateSet map[string]bool
+
+// excluded reports whether c is excluded given gates. A condition only
+// excludes the constant when the referenced gate's state is known; an
+// unresolved gate is never treated as a match, matching how the generator
+// narrows ConditionalEnum for a partially-specified build configuration.
+func excluded(c EnumConstant, gates GateSet) bool {
+	for _, cond := range c.Exclusions {
+		enabled, known := gates[cond.Gate]
+		if known && enabled == cond.RequireEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportEnum narrows consts against gates and returns the enum schema for the
+// result, in declaration order. With no gates specified at all, nothing is
+// excluded (every exclusion's gate is unknown), so the full set is emitted.
+func ExportEnum(consts []EnumConstant, gates GateSet) Schema {
+	var s Schema
+	for _, c := range consts {
+		if !excluded(c, gates) {
+			s.Enum = append(s.Enum, c.Value)
+		}
+	}
+	return s
+}
+
+// ExportEnumVariants emits a oneOf schema with one variant per resolution of
+// the gates referenced by consts, for use when the gate configuration is not
+// known until runtime (e.g. a CRD schema shipped ahead of the feature gates
+// it depends on being finalized). Resolutions that yield the same enum list
+// share a single variant. If consts reference no gates at all, the plain enum
+// schema from ExportEnum is returned, with no oneOf.
+func ExportEnumVariants(consts []EnumConstant) Schema {
+	gateNames := referencedGates(consts)
+	if len(gateNames) == 0 {
+		return ExportEnum(consts, nil)
+	}
+
+	var variants []Schema
+	for _, combo := range powerset(gateNames) {
+		variants = append(variants, ExportEnum(consts, combo))
+	}
+	return Schema{OneOf: dedupe(variants)}
+}
+
+func referencedGates(consts []EnumConstant) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, c := range consts {
+		for _, cond := range c.Exclusions {
+			if !seen[cond.Gate] {
+				seen[cond.Gate] = true
+				names = append(names, cond.Gate)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// powerset returns every enabled/disabled assignment of the given gate names.
+func powerset(names []string) []GateSet {
+	if len(names) == 0 {
+		return []GateSet{{}}
+	}
+	rest := powerset(names[1:])
+	var out []GateSet
+	for _, enabled := range []bool{false, true} {
+		for _, r := range rest {
+			combo := GateSet{names[0]: enabled}
+			for k, v := range r {
+				combo[k] = v
+			}
+			out = append(out, combo)
+		}
+	}
+	return out
+}
+
+func dedupe(schemas []Schema) []Schema {
+	seen := map[string]bool{}
+	var out []Schema
+	for _, s := range schemas {
+		key := ""
+		for _, v := range s.Enum {
+			key += v + ","
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+// conditionalEnumConstantsPath is tags/enum.ConditionalEnum's source file
+// (conditional.go), read directly by ExtractEnumConstants below instead of
+// hand-transcribing its +k8s:ifEnabled/+k8s:ifDisabled markers, so a change
+// to those markers fails this test instead of silently going stale.
+const conditionalEnumConstantsPath = "../output_tests/tags/enum/conditional.go"
+
+func conditionalEnumConstants(t *testing.T) []EnumConstant {
+	t.Helper()
+	consts, err := ExtractEnumConstants(conditionalEnumConstantsPath, "ConditionalEnum")
+	if err != nil {
+		t.Fatalf("ExtractEnumConstants() error = %v", err)
+	}
+	return consts
+}
+
+func TestExtractEnumConstants(t *testing.T) {
+	want := []EnumConstant{
+		{Value: "A", Exclusions: []GateCondition{{Gate: "FeatureA", RequireEnabled: true}}},
+		{Value: "B", Exclusions: []GateCondition{{Gate: "FeatureB", RequireEnabled: false}}},
+		{Value: "C"},
+		{Value: "D", Exclusions: []GateCondition{
+			{Gate: "FeatureA", RequireEnabled: true},
+			{Gate: "FeatureB", RequireEnabled: true},
+		}},
+		{Value: "E", Exclusions: []GateCondition{
+			{Gate: "FeatureC", RequireEnabled: false},
+			{Gate: "FeatureD", RequireEnabled: false},
+		}},
+		{Value: "F", Exclusions: []GateCondition{
+			{Gate: "FeatureC", RequireEnabled: false},
+			{Gate: "FeatureD", RequireEnabled: true},
+		}},
+	}
+
+	got := conditionalEnumConstants(t)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractEnumConstants() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractStructFieldMarkers(t *testing.T) {
+	fields, err := ExtractStructFieldMarkers("../output_tests/tags/maxproperties/types.go", "Struct")
+	if err != nil {
+		t.Fatalf("ExtractStructFieldMarkers() error = %v", err)
+	}
+
+	want := FieldMarkers{"maxProperties": {"10"}}
+	if got := fields["Max10Field"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields[%q] = %+v, want %+v", "Max10Field", got, want)
+	}
+
+	want = FieldMarkers{"minProperties": {"2"}}
+	if got := fields["MinPropertiesField"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields[%q] = %+v, want %+v", "MinPropertiesField", got, want)
+	}
+
+	want = FieldMarkers{"maxProperties": {"10"}, "keyPattern": {"^x-"}, "valuePattern": {"^[0-9]+$"}}
+	if got := fields["PatternConstrainedField"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields[%q] = %+v, want %+v", "PatternConstrainedField", got, want)
+	}
+}
+
+func TestExportEnum(t *testing.T) {
+	consts := conditionalEnumConstants(t)
+
+	got := ExportEnum(consts, GateSet{"FeatureA": true, "FeatureB": false})
+	want := Schema{Enum: []string{"C", "E", "F"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportEnum() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExportEnum_NoGates(t *testing.T) {
+	consts := conditionalEnumConstants(t)
+
+	got := ExportEnum(consts, nil)
+	want := Schema{Enum: []string{"A", "B", "C", "D", "E", "F"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportEnum(nil) = %+v, want %+v", got, want)
+	}
+}
+
+func TestExportEnumVariants(t *testing.T) {
+	consts := []EnumConstant{
+		{Value: "A", Exclusions: []GateCondition{{Gate: "FeatureA", RequireEnabled: true}}},
+		{Value: "C"},
+	}
+
+	got := ExportEnumVariants(consts)
+	if len(got.OneOf) != 2 {
+		t.Fatalf("ExportEnumVariants() produced %d variants, want 2", len(got.OneOf))
+	}
+
+	var sawAll, sawCOnly bool
+	for _, v := range got.OneOf {
+		switch {
+		case reflect.DeepEqual(v.Enum, []string{"A", "C"}):
+			sawAll = true
+		case reflect.DeepEqual(v.Enum, []string{"C"}):
+			sawCOnly = true
+		}
+	}
+	if !sawAll || !sawCOnly {
+		t.Errorf("ExportEnumVariants() = %+v, missing expected gate-on/gate-off variants", got.OneOf)
+	}
+}
+
+func int64p(v int64) *int64       { return &v }
+func float64p(v float64) *float64 { return &v }
+
+func TestSchemaExporter_ExportField(t *testing.T) {
+	var exporter SchemaExporter
+
+	got, err := exporter.ExportField(FieldMarkers{
+		"maxProperties": {"10"},
+		"minProperties": {"1"},
+		"maxLength":     {"63"},
+		"minLength":     {"1"},
+		"minimum":       {"0"},
+		"maximum":       {"100.5"},
+	})
+	if err != nil {
+		t.Fatalf("ExportField() error = %v", err)
+	}
+	want := Schema{
+		MaxProperties: int64p(10),
+		MinProperties: int64p(1),
+		MaxLength:     int64p(63),
+		MinLength:     int64p(1),
+		Minimum:       float64p(0),
+		Maximum:       float64p(100.5),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportField() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSchemaExporter_ExportField_Partial(t *testing.T) {
+	var exporter SchemaExporter
+
+	got, err := exporter.ExportField(FieldMarkers{"maxProperties": {"5"}})
+	if err != nil {
+		t.Fatalf("ExportField() error = %v", err)
+	}
+	if got.MaxProperties == nil || *got.MaxProperties != 5 {
+		t.Errorf("ExportField() = %+v, want MaxProperties=5", got)
+	}
+	if got.MinProperties != nil || got.MaxLength != nil || got.MinLength != nil || got.Minimum != nil || got.Maximum != nil {
+		t.Errorf("ExportField() = %+v, want all other fields unset", got)
+	}
+}
+
+func TestSchemaExporter_ExportField_Errors(t *testing.T) {
+	var exporter SchemaExporter
+
+	if _, err := exporter.ExportField(FieldMarkers{"maxProperties": {"not-a-number"}}); err == nil {
+		t.Error("ExportField() with a non-numeric argument: expected an error, got none")
+	}
+	if _, err := exporter.ExportField(FieldMarkers{"enum": {"A"}}); err == nil {
+		t.Error("ExportField() with an unrecognized marker: expected an error, got none")
+	}
+}
+
+func TestSchemaExporter_ExportEnum(t *testing.T) {
+	exporter := SchemaExporter{Gates: GateSet{"FeatureA": true, "FeatureB": false}}
+
+	got := exporter.ExportEnum(conditionalEnumConstants(t))
+	want := Schema{Enum: []string{"C", "E", "F"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaExporter.ExportEnum() = %+v, want %+v", got, want)
+	}
+}